@@ -0,0 +1,77 @@
+/*
+ * Copyright (C) 2020, MinIO, Inc.
+ *
+ * This code is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License, version 3,
+ * as published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License, version 3,
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>
+ *
+ */
+
+package v1
+
+import corev1 "k8s.io/api/core/v1"
+
+// BootstrapSpec enables the opt-in post-install provisioning Job that
+// applies Spec.Buckets/Users/Policies via `mc` once the tenant is up.
+type BootstrapSpec struct {
+	// Image is the `mc` image the bootstrap Job runs.
+	Image string `json:"image"`
+}
+
+// ObjectLockingSpec configures default object locking for a bucket.
+type ObjectLockingSpec struct {
+	// Mode is "governance" or "compliance".
+	Mode string `json:"mode,omitempty"`
+	// ValidityDays is the default retention period, in days.
+	ValidityDays int `json:"validityDays,omitempty"`
+}
+
+// LifecycleRule is a single ILM rule applied to a bucket.
+type LifecycleRule struct {
+	// ExpiryDays is the number of days after which objects matching the
+	// rule expire.
+	ExpiryDays int `json:"expiryDays"`
+}
+
+// BucketSpec describes a bucket the bootstrap Job creates and configures.
+type BucketSpec struct {
+	// Name is the bucket name.
+	Name string `json:"name"`
+	// Versioning, when true, enables object versioning on the bucket.
+	Versioning bool `json:"versioning,omitempty"`
+	// ObjectLocking configures default object locking on the bucket.
+	ObjectLocking ObjectLockingSpec `json:"objectLocking,omitempty"`
+	// Quota is the bucket's hard storage quota, e.g. "10GiB".
+	Quota string `json:"quota,omitempty"`
+	// LifecycleRules are the ILM rules applied to the bucket.
+	LifecycleRules []LifecycleRule `json:"lifecycleRules,omitempty"`
+}
+
+// UserSpec describes an additional MinIO user the bootstrap Job creates.
+type UserSpec struct {
+	// Name identifies the user within the tenant; it's not the MinIO
+	// access key.
+	Name string `json:"name"`
+	// CredentialsSecret is a Secret with "accesskey"/"secretkey" keys for
+	// the user.
+	CredentialsSecret *corev1.LocalObjectReference `json:"credentialsSecret"`
+	// Policies lists the names of Spec.Policies (or built-in MinIO
+	// policies) to attach to the user.
+	Policies []string `json:"policies,omitempty"`
+}
+
+// PolicySpec describes a custom IAM policy the bootstrap Job creates.
+type PolicySpec struct {
+	// Name is the policy name referenced by UserSpec.Policies.
+	Name string `json:"name"`
+	// ConfigMap holds the policy document under a "policy.json" key.
+	ConfigMap *corev1.LocalObjectReference `json:"configMap"`
+}
@@ -0,0 +1,278 @@
+/*
+ * Copyright (C) 2020, MinIO, Inc.
+ *
+ * This code is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License, version 3,
+ * as published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License, version 3,
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>
+ *
+ */
+
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto copies every field of mi into out, including the contents of
+// every pointer, slice, and map, so a caller can safely mutate either copy
+// without affecting the other. Hand-maintained pending deepcopy-gen.
+func (mi *MinIOInstance) DeepCopyInto(out *MinIOInstance) {
+	*out = *mi
+	out.TypeMeta = mi.TypeMeta
+	mi.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	mi.Spec.DeepCopyInto(&out.Spec)
+	out.Status = mi.Status
+}
+
+// DeepCopy returns a deep copy of mi.
+func (mi *MinIOInstance) DeepCopy() *MinIOInstance {
+	if mi == nil {
+		return nil
+	}
+	out := new(MinIOInstance)
+	mi.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject implements runtime.Object.
+func (mi *MinIOInstance) DeepCopyObject() runtime.Object {
+	return mi.DeepCopy()
+}
+
+// DeepCopyInto copies every field of in into out.
+func (in *MinIOInstanceSpec) DeepCopyInto(out *MinIOInstanceSpec) {
+	*out = *in
+	if in.Zones != nil {
+		out.Zones = make([]Zone, len(in.Zones))
+		for i := range in.Zones {
+			in.Zones[i].DeepCopyInto(&out.Zones[i])
+		}
+	}
+	if in.VolumeClaimTemplate != nil {
+		out.VolumeClaimTemplate = in.VolumeClaimTemplate.DeepCopy()
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Liveness != nil {
+		out.Liveness = in.Liveness.DeepCopy()
+	}
+	if in.Readiness != nil {
+		out.Readiness = in.Readiness.DeepCopy()
+	}
+	if in.Env != nil {
+		out.Env = make([]corev1.EnvVar, len(in.Env))
+		for i := range in.Env {
+			in.Env[i].DeepCopyInto(&out.Env[i])
+		}
+	}
+	if in.CredsSecret != nil {
+		c := *in.CredsSecret
+		out.CredsSecret = &c
+	}
+	if in.ExternalCertSecret != nil {
+		c := *in.ExternalCertSecret
+		out.ExternalCertSecret = &c
+	}
+	if in.KES != nil {
+		c := *in.KES
+		out.KES = &c
+	}
+	if in.Metadata != nil {
+		out.Metadata = in.Metadata.DeepCopy()
+	}
+	if in.Selector != nil {
+		out.Selector = in.Selector.DeepCopy()
+	}
+	if in.Affinity != nil {
+		out.Affinity = in.Affinity.DeepCopy()
+	}
+	if in.Tolerations != nil {
+		out.Tolerations = make([]corev1.Toleration, len(in.Tolerations))
+		for i := range in.Tolerations {
+			in.Tolerations[i].DeepCopyInto(&out.Tolerations[i])
+		}
+	}
+	if in.SecurityContext != nil {
+		out.SecurityContext = in.SecurityContext.DeepCopy()
+	}
+	if in.Monitoring != nil {
+		out.Monitoring = in.Monitoring.DeepCopy()
+	}
+	if in.KMS != nil {
+		out.KMS = in.KMS.DeepCopy()
+	}
+	if in.Bootstrap != nil {
+		c := *in.Bootstrap
+		out.Bootstrap = &c
+	}
+	if in.Buckets != nil {
+		out.Buckets = make([]BucketSpec, len(in.Buckets))
+		for i := range in.Buckets {
+			in.Buckets[i].DeepCopyInto(&out.Buckets[i])
+		}
+	}
+	if in.Users != nil {
+		out.Users = make([]UserSpec, len(in.Users))
+		for i := range in.Users {
+			in.Users[i].DeepCopyInto(&out.Users[i])
+		}
+	}
+	if in.Policies != nil {
+		out.Policies = make([]PolicySpec, len(in.Policies))
+		for i := range in.Policies {
+			in.Policies[i].DeepCopyInto(&out.Policies[i])
+		}
+	}
+}
+
+// DeepCopyInto copies every field of in into out.
+func (in *Zone) DeepCopyInto(out *Zone) {
+	*out = *in
+	in.UpdateStrategy.DeepCopyInto(&out.UpdateStrategy)
+	if in.PDB != nil {
+		out.PDB = in.PDB.DeepCopy()
+	}
+}
+
+// DeepCopy returns a deep copy of in.
+func (in *PDBSpec) DeepCopy() *PDBSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PDBSpec)
+	*out = *in
+	if in.MinAvailable != nil {
+		v := *in.MinAvailable
+		out.MinAvailable = &v
+	}
+	if in.MaxUnavailable != nil {
+		v := *in.MaxUnavailable
+		out.MaxUnavailable = &v
+	}
+	return out
+}
+
+// DeepCopy returns a deep copy of in.
+func (in *MonitoringSpec) DeepCopy() *MonitoringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringSpec)
+	*out = *in
+	in.SidecarResources.DeepCopyInto(&out.SidecarResources)
+	return out
+}
+
+// DeepCopy returns a deep copy of in.
+func (in *KMSSpec) DeepCopy() *KMSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KMSSpec)
+	*out = *in
+	if in.Vault != nil {
+		out.Vault = in.Vault.DeepCopy()
+	}
+	if in.AWS != nil {
+		out.AWS = in.AWS.DeepCopy()
+	}
+	if in.GCP != nil {
+		out.GCP = in.GCP.DeepCopy()
+	}
+	return out
+}
+
+// DeepCopy returns a deep copy of in.
+func (in *VaultSpec) DeepCopy() *VaultSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultSpec)
+	*out = *in
+	if in.AppRoleSecret != nil {
+		c := *in.AppRoleSecret
+		out.AppRoleSecret = &c
+	}
+	return out
+}
+
+// DeepCopy returns a deep copy of in.
+func (in *AWSKMSSpec) DeepCopy() *AWSKMSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSKMSSpec)
+	*out = *in
+	if in.CredentialsSecret != nil {
+		c := *in.CredentialsSecret
+		out.CredentialsSecret = &c
+	}
+	return out
+}
+
+// DeepCopy returns a deep copy of in.
+func (in *GCPKMSSpec) DeepCopy() *GCPKMSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPKMSSpec)
+	*out = *in
+	if in.CredentialsSecret != nil {
+		c := *in.CredentialsSecret
+		out.CredentialsSecret = &c
+	}
+	return out
+}
+
+// DeepCopyInto copies every field of in into out.
+func (in *BucketSpec) DeepCopyInto(out *BucketSpec) {
+	*out = *in
+	if in.LifecycleRules != nil {
+		out.LifecycleRules = make([]LifecycleRule, len(in.LifecycleRules))
+		copy(out.LifecycleRules, in.LifecycleRules)
+	}
+}
+
+// DeepCopyInto copies every field of in into out.
+func (in *UserSpec) DeepCopyInto(out *UserSpec) {
+	*out = *in
+	if in.CredentialsSecret != nil {
+		c := *in.CredentialsSecret
+		out.CredentialsSecret = &c
+	}
+	if in.Policies != nil {
+		out.Policies = make([]string, len(in.Policies))
+		copy(out.Policies, in.Policies)
+	}
+}
+
+// DeepCopyInto copies every field of in into out.
+func (in *PolicySpec) DeepCopyInto(out *PolicySpec) {
+	*out = *in
+	if in.ConfigMap != nil {
+		c := *in.ConfigMap
+		out.ConfigMap = &c
+	}
+}
+
+// DeepCopyObject implements runtime.Object.
+func (l *MinIOInstanceList) DeepCopyObject() runtime.Object {
+	out := new(MinIOInstanceList)
+	out.TypeMeta = l.TypeMeta
+	l.ListMeta.DeepCopyInto(&out.ListMeta)
+	if l.Items != nil {
+		out.Items = make([]MinIOInstance, len(l.Items))
+		for i := range l.Items {
+			l.Items[i].DeepCopyInto(&out.Items[i])
+		}
+	}
+	return out
+}
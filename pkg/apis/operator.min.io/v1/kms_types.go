@@ -0,0 +1,75 @@
+/*
+ * Copyright (C) 2020, MinIO, Inc.
+ *
+ * This code is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License, version 3,
+ * as published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License, version 3,
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>
+ *
+ */
+
+package v1
+
+import corev1 "k8s.io/api/core/v1"
+
+// KMSSpec configures the tenant's server-side encryption backend. Provider
+// selects which of Vault/AWS/GCP is active; "kes" is also accepted for
+// tenants migrating off the legacy KES field without changing backends.
+type KMSSpec struct {
+	// Provider is one of "kes", "vault", "aws", or "gcp".
+	Provider string      `json:"provider"`
+	Vault    *VaultSpec  `json:"vault,omitempty"`
+	AWS      *AWSKMSSpec `json:"aws,omitempty"`
+	GCP      *GCPKMSSpec `json:"gcp,omitempty"`
+}
+
+// VaultSpec configures a HashiCorp Vault transit backend as the tenant's
+// KMS.
+type VaultSpec struct {
+	// Endpoint is the Vault server's address.
+	Endpoint string `json:"endpoint"`
+	// KeyName is the transit key MinIO uses to derive per-object keys.
+	KeyName string `json:"keyName"`
+	// Namespace is the Vault Enterprise namespace the key lives in, if
+	// any.
+	Namespace string `json:"namespace,omitempty"`
+	// KeyVersion pins the transit key version to use. Zero means the
+	// latest.
+	KeyVersion int `json:"keyVersion,omitempty"`
+	// AppRoleSecret is a Secret with "roleid"/"secretid" keys used to
+	// authenticate against Vault's AppRole auth method.
+	AppRoleSecret *corev1.LocalObjectReference `json:"appRoleSecret,omitempty"`
+}
+
+// AWSKMSSpec configures AWS KMS as the tenant's KMS.
+type AWSKMSSpec struct {
+	// Region is the AWS region the key lives in.
+	Region string `json:"region"`
+	// KeyID is the AWS KMS key ID or alias to use.
+	KeyID string `json:"keyId"`
+	// CredentialsSecret is a Secret with "accesskey"/"secretkey" keys for
+	// an IAM principal authorized to use KeyID. When unset, the
+	// instance's IAM role is used instead.
+	CredentialsSecret *corev1.LocalObjectReference `json:"credentialsSecret,omitempty"`
+}
+
+// GCPKMSSpec configures Google Cloud KMS as the tenant's KMS.
+type GCPKMSSpec struct {
+	// ProjectID is the GCP project the key ring lives in.
+	ProjectID string `json:"projectId"`
+	// KeyRing is the GCP KMS key ring name.
+	KeyRing string `json:"keyRing"`
+	// KeyName is the GCP KMS key name within KeyRing.
+	KeyName string `json:"keyName"`
+	// CredentialsSecret is a Secret with a "credentials.json" key holding
+	// a GCP service account key. When unset, the instance's default
+	// service account is used instead.
+	CredentialsSecret *corev1.LocalObjectReference `json:"credentialsSecret,omitempty"`
+}
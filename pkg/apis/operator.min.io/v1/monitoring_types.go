@@ -0,0 +1,62 @@
+/*
+ * Copyright (C) 2020, MinIO, Inc.
+ *
+ * This code is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License, version 3,
+ * as published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License, version 3,
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>
+ *
+ */
+
+package v1
+
+import corev1 "k8s.io/api/core/v1"
+
+const (
+	// PrometheusMetricsPath is the MinIO server endpoint scraped for
+	// cluster-wide metrics.
+	PrometheusMetricsPath = "/minio/v2/metrics/cluster"
+	// DefaultSidecarImage is used for the monitoring sidecar container
+	// when Spec.Monitoring.SidecarImage is left unset.
+	DefaultSidecarImage = "minio/minio-monitoring-sidecar:v1.0.0"
+)
+
+// MonitoringSpec configures Prometheus scraping for a tenant.
+type MonitoringSpec struct {
+	// PrometheusURL is advertised to the MinIO server via
+	// MINIO_PROMETHEUS_URL so its own embedded metrics can be queried
+	// back through Prometheus.
+	PrometheusURL string `json:"prometheusURL,omitempty"`
+	// Sidecar, when true, runs a dedicated metrics-scraping sidecar
+	// alongside the MinIO container.
+	Sidecar bool `json:"sidecar,omitempty"`
+	// SidecarImage overrides DefaultSidecarImage for the monitoring
+	// sidecar container.
+	SidecarImage string `json:"sidecarImage,omitempty"`
+	// SidecarResources are the compute resources required by the
+	// monitoring sidecar container.
+	SidecarResources corev1.ResourceRequirements `json:"sidecarResources,omitempty"`
+}
+
+// HasMonitoringEnabled returns true when the tenant has Prometheus
+// monitoring configured.
+func (mi *MinIOInstance) HasMonitoringEnabled() bool {
+	return mi.Spec.Monitoring != nil
+}
+
+// SidecarImage returns Spec.Monitoring.SidecarImage, falling back to
+// DefaultSidecarImage when it's unset so enabling the sidecar never
+// produces an empty image reference.
+func (mi *MinIOInstance) SidecarImage() string {
+	if mi.Spec.Monitoring != nil && mi.Spec.Monitoring.SidecarImage != "" {
+		return mi.Spec.Monitoring.SidecarImage
+	}
+	return DefaultSidecarImage
+}
@@ -0,0 +1,370 @@
+/*
+ * Copyright (C) 2020, MinIO, Inc.
+ *
+ * This code is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License, version 3,
+ * as published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License, version 3,
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>
+ *
+ */
+
+package v1
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// MinIOCRDResourceKind is the Kind carried on the OwnerReference every
+	// child resource this operator creates points back at.
+	MinIOCRDResourceKind = "MinIOInstance"
+	// MinIOServerName is the container name used for the MinIO server.
+	MinIOServerName = "minio"
+	// MinIOPort is the port the MinIO server listens for S3 and admin
+	// traffic on.
+	MinIOPort = 9000
+	// Scheme is the URL scheme used to address MinIO peers in the
+	// `server` command's host list.
+	Scheme = "http"
+	// MinIOVolumeName is the base name of the storage volume mounts in
+	// the MinIO container when Spec.VolumeClaimTemplate is nil.
+	MinIOVolumeName = "export"
+	// MinIOVolumeMountPath is the base path each storage volume is
+	// mounted at inside the MinIO container.
+	MinIOVolumeMountPath = "/export"
+	// InstanceLabel identifies which MinIOInstance a child resource
+	// belongs to.
+	InstanceLabel = "v1.min.io/instance"
+	// ZoneLabel identifies which zone within a MinIOInstance a child
+	// resource belongs to.
+	ZoneLabel = "v1.min.io/zone"
+	// KESPort is the port the KES server listens on.
+	KESPort = 7373
+	// KESMinIOKey is the default key name KES mints for MinIO to use for
+	// server-side encryption.
+	KESMinIOKey = "my-minio-key"
+)
+
+// DefaultImagePullPolicy is the ImagePullPolicy applied to every container
+// this operator builds.
+const DefaultImagePullPolicy = corev1.PullIfNotPresent
+
+// DefaultUpdateStrategy is the StatefulSet update strategy applied when
+// the zone doesn't set its own UpdateStrategy.
+const DefaultUpdateStrategy = appsv1.RollingUpdateStatefulSetStrategyType
+
+// CertificateConfig references an externally managed TLS Secret, such as one
+// produced by cert-manager, to use instead of the operator's own
+// auto-generated certificates.
+type CertificateConfig struct {
+	// Name of the Secret holding the certificate.
+	Name string `json:"name"`
+	// Type identifies the Secret's layout, e.g. "kubernetes.io/tls" or
+	// "cert-manager.io/v1alpha2".
+	Type string `json:"type,omitempty"`
+}
+
+// KESConfig points the tenant at a KES server for server-side encryption.
+// Deprecated: set Spec.KMS with Provider "kes" instead; this is kept only
+// for tenants created before Spec.KMS existed.
+type KESConfig struct {
+	// Image is the KES server image, required when the operator also
+	// manages the KES deployment.
+	Image string `json:"image,omitempty"`
+}
+
+// Zone is a group of MinIO servers within a tenant that is rolled and
+// disrupted independently of every other zone, each backed by its own
+// StatefulSet.
+type Zone struct {
+	// Name uniquely identifies the zone within the tenant.
+	Name string `json:"name"`
+	// Servers is the number of MinIO server pods in the zone.
+	Servers int `json:"servers"`
+	// UpdateStrategy overrides DefaultUpdateStrategy (RollingUpdate) for
+	// this zone's StatefulSet.
+	UpdateStrategy appsv1.StatefulSetUpdateStrategy `json:"updateStrategy,omitempty"`
+	// PodManagementPolicy overrides Spec.PodManagementPolicy for this
+	// zone's StatefulSet.
+	PodManagementPolicy appsv1.PodManagementPolicyType `json:"podManagementPolicy,omitempty"`
+	// PDB, when set, has the operator create a PodDisruptionBudget
+	// scoped to this zone's pods.
+	PDB *PDBSpec `json:"pdb,omitempty"`
+}
+
+// PDBSpec configures the PodDisruptionBudget the operator creates for a
+// zone. Exactly one of MinAvailable or MaxUnavailable must be set, matching
+// the constraint the Kubernetes API server itself enforces on
+// PodDisruptionBudgetSpec.
+type PDBSpec struct {
+	MinAvailable   *intstr.IntOrString `json:"minAvailable,omitempty"`
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// MinIOInstanceSpec defines the desired state of a MinIOInstance.
+type MinIOInstanceSpec struct {
+	// Zones is the list of server groups that make up the tenant. A
+	// single zone with one server deploys MinIO in standalone mode.
+	Zones []Zone `json:"zones"`
+	// Image is the MinIO server image.
+	Image string `json:"image,omitempty"`
+	// ImagePullSecret is the Secret used to pull Image, if private.
+	ImagePullSecret corev1.LocalObjectReference `json:"imagePullSecret,omitempty"`
+	// VolumesPerServer is the number of storage volumes mounted into each
+	// server pod.
+	VolumesPerServer int `json:"volumesPerServer,omitempty"`
+	// VolumeClaimTemplate is the PVC template used for each storage
+	// volume. When nil, each server pod uses an EmptyDir instead.
+	VolumeClaimTemplate *corev1.PersistentVolumeClaim `json:"volumeClaimTemplate,omitempty"`
+	// Resources are the compute resources required by the MinIO
+	// container.
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Liveness is the liveness probe for the MinIO container.
+	Liveness *corev1.Probe `json:"liveness,omitempty"`
+	// Readiness is the readiness probe for the MinIO container.
+	Readiness *corev1.Probe `json:"readiness,omitempty"`
+	// Env is a list of additional environment variables to set on the
+	// MinIO container.
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// CredsSecret is a Secret with "accesskey"/"secretkey" keys used as
+	// the tenant's root credentials. When unset, MinIO generates and
+	// prints its own default credentials.
+	CredsSecret *corev1.LocalObjectReference `json:"credsSecret,omitempty"`
+	// ExternalCertSecret references an externally managed TLS Secret to
+	// use instead of RequestAutoCert.
+	ExternalCertSecret *CertificateConfig `json:"externalCertSecret,omitempty"`
+	// RequestAutoCert, when true, has the operator mint and manage its
+	// own self-signed TLS certificate for the tenant.
+	RequestAutoCert bool `json:"requestAutoCert,omitempty"`
+	// KES is the legacy way to enable KES-backed server-side encryption.
+	// Deprecated: set KMS instead.
+	KES *KESConfig `json:"kes,omitempty"`
+	// Metadata is merged onto every pod this tenant creates, after the
+	// labels/annotations inherited from the MinIOInstance's own
+	// ObjectMeta and before the operator's reserved labels.
+	Metadata *metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Selector, when set, has its MatchLabels merged onto the pod
+	// template's labels, below the operator's reserved labels. The
+	// StatefulSet's own selector uses only the reserved labels.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// Affinity is the affinity rules applied to every server pod.
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+	// Tolerations are applied to every server pod.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// SecurityContext is the pod security context applied to every
+	// server pod.
+	SecurityContext *corev1.PodSecurityContext `json:"securityContext,omitempty"`
+	// PodManagementPolicy is the StatefulSet pod management policy used
+	// when a zone doesn't set its own.
+	PodManagementPolicy appsv1.PodManagementPolicyType `json:"podManagementPolicy,omitempty"`
+	// ClusterDomain is the cluster's DNS domain, used to build peer
+	// hostnames. Defaults to "cluster.local".
+	ClusterDomain string `json:"clusterDomain,omitempty"`
+	// HostsTemplate, when set, overrides the operator's default
+	// zone-aware peer hostnames. It's parsed as a text/template and
+	// executed once per replica.
+	HostsTemplate string `json:"hostsTemplate,omitempty"`
+	// Monitoring configures Prometheus scraping for the tenant.
+	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
+	// MaxUnavailableZones caps how many zones the controller rolls at
+	// once when updating a tenant with more than one zone. Defaults to 1,
+	// so by default zones are updated strictly one at a time.
+	MaxUnavailableZones int `json:"maxUnavailableZones,omitempty"`
+	// KMS configures the tenant's server-side encryption backend.
+	// Provider selects between the mutually exclusive Vault/AWS/GCP
+	// blocks; a tenant that only sets the legacy KES field is still
+	// treated as using KES.
+	KMS *KMSSpec `json:"kms,omitempty"`
+	// Bootstrap, when set, enables the opt-in post-install provisioning
+	// of Buckets, Users, and Policies via `mc`.
+	Bootstrap *BootstrapSpec `json:"bootstrap,omitempty"`
+	// Buckets lists the buckets to create (and configure) once the
+	// tenant is up.
+	Buckets []BucketSpec `json:"buckets,omitempty"`
+	// Users lists the additional MinIO users to create once the tenant
+	// is up.
+	Users []UserSpec `json:"users,omitempty"`
+	// Policies lists the custom IAM policies to create once the tenant
+	// is up, for Users to reference.
+	Policies []PolicySpec `json:"policies,omitempty"`
+}
+
+// DefaultMaxUnavailableZones is used when Spec.MaxUnavailableZones is left
+// unset, so multi-zone tenants roll one zone at a time unless a tenant
+// explicitly opts into more parallelism.
+const DefaultMaxUnavailableZones = 1
+
+// MaxUnavailableZones returns Spec.MaxUnavailableZones, defaulting to
+// DefaultMaxUnavailableZones when unset or non-positive.
+func (mi *MinIOInstance) MaxUnavailableZones() int {
+	if mi.Spec.MaxUnavailableZones > 0 {
+		return mi.Spec.MaxUnavailableZones
+	}
+	return DefaultMaxUnavailableZones
+}
+
+// MinIOInstanceStatus is the observed state of a MinIOInstance.
+type MinIOInstanceStatus struct {
+	// CurrentState is a human-readable summary of the tenant's state.
+	CurrentState string `json:"currentState,omitempty"`
+	// AvailableReplicas is the number of MinIO server pods that are
+	// currently Ready.
+	AvailableReplicas int32 `json:"availableReplicas,omitempty"`
+	// HostsTemplateError surfaces the most recent error parsing or
+	// executing Spec.HostsTemplate, if any.
+	HostsTemplateError string `json:"hostsTemplateError,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MinIOInstance is a MinIO tenant.
+type MinIOInstance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Scheduler is the scheduler used to place the tenant's server pods.
+	Scheduler corev1.LocalObjectReference `json:"scheduler,omitempty"`
+
+	Spec   MinIOInstanceSpec   `json:"spec"`
+	Status MinIOInstanceStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MinIOInstanceList is a list of MinIOInstances.
+type MinIOInstanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []MinIOInstance `json:"items"`
+}
+
+// DeepCopyObject, DeepCopy, and DeepCopyInto for MinIOInstance, and
+// DeepCopyObject for MinIOInstanceList, are defined in deepcopy.go.
+
+// HasCredsSecret returns true when the tenant points at a Secret with
+// self-provided root credentials instead of MinIO's auto-generated default.
+func (mi *MinIOInstance) HasCredsSecret() bool {
+	return mi.Spec.CredsSecret != nil && mi.Spec.CredsSecret.Name != ""
+}
+
+// HasKESEnabled returns true when the tenant has legacy KES-backed
+// server-side encryption configured.
+func (mi *MinIOInstance) HasKESEnabled() bool {
+	return mi.Spec.KES != nil
+}
+
+// HasMetadata returns true when Spec.Metadata is set.
+func (mi *MinIOInstance) HasMetadata() bool {
+	return mi.Spec.Metadata != nil
+}
+
+// HasSelector returns true when Spec.Selector is set.
+func (mi *MinIOInstance) HasSelector() bool {
+	return mi.Spec.Selector != nil
+}
+
+// HasBootstrapEnabled returns true when the tenant has opted into
+// post-install provisioning of Spec.Buckets/Users/Policies via `mc`.
+func (mi *MinIOInstance) HasBootstrapEnabled() bool {
+	return mi.Spec.Bootstrap != nil
+}
+
+// RequiresAutoCertSetup returns true when the operator must mint and manage
+// its own TLS certificate for the tenant.
+func (mi *MinIOInstance) RequiresAutoCertSetup() bool {
+	return mi.Spec.RequestAutoCert
+}
+
+// RequiresExternalCertSetup returns true when the tenant points at an
+// externally managed TLS Secret.
+func (mi *MinIOInstance) RequiresExternalCertSetup() bool {
+	return mi.Spec.ExternalCertSecret != nil
+}
+
+// MinIOStatefulSetName is the base name shared by every zone's StatefulSet
+// for this tenant; each zone's actual StatefulSet is named
+// "<MinIOStatefulSetName()>-<zone.Name>".
+func (mi *MinIOInstance) MinIOStatefulSetName() string {
+	return mi.Name + "-ss"
+}
+
+// MinIOPodLabels returns the labels shared by every zone's server pods in
+// this tenant, used to address the whole tenant via a single headless
+// Service regardless of which zone a pod belongs to.
+func (mi *MinIOInstance) MinIOPodLabels() map[string]string {
+	return map[string]string{
+		InstanceLabel: mi.MinIOStatefulSetName(),
+	}
+}
+
+// MinIOReplicas returns the total number of server pods across every zone.
+func (mi *MinIOInstance) MinIOReplicas() int32 {
+	var replicas int32
+	for _, zone := range mi.Spec.Zones {
+		replicas += int32(zone.Servers)
+	}
+	return replicas
+}
+
+// VolumePath returns the MinIO container path argument for this tenant's
+// storage volumes: a single mount point, or an ellipsis range across
+// Spec.VolumesPerServer mounts.
+func (mi *MinIOInstance) VolumePath() string {
+	if mi.Spec.VolumesPerServer <= 1 {
+		return MinIOVolumeMountPath
+	}
+	return fmt.Sprintf("%s{0...%d}", MinIOVolumeMountPath, mi.Spec.VolumesPerServer-1)
+}
+
+// MinIOTLSSecretName is the Secret holding the tenant's server TLS
+// certificate, whether auto-generated or externally managed.
+func (mi *MinIOInstance) MinIOTLSSecretName() string {
+	if mi.RequiresExternalCertSetup() {
+		return mi.Spec.ExternalCertSecret.Name
+	}
+	return mi.Name + "-tls"
+}
+
+// MinIOClientTLSSecretName is the Secret holding the client certificate
+// MinIO presents to KES.
+func (mi *MinIOInstance) MinIOClientTLSSecretName() string {
+	return mi.Name + "-client-tls"
+}
+
+// KESTLSSecretName is the Secret holding the KES server's CA certificate.
+func (mi *MinIOInstance) KESTLSSecretName() string {
+	return mi.Name + "-kes-tls"
+}
+
+// KESServiceHost is the in-cluster DNS name of the tenant's KES service.
+func (mi *MinIOInstance) KESServiceHost() string {
+	return mi.Name + "-kes." + mi.Namespace + ".svc.cluster.local"
+}
+
+// MinIOHosts returns the replica hostnames using the operator's original,
+// non-zone-aware CoreDNS naming. Superseded by the zone-aware default that
+// pkg/resources/statefulsets falls back to when Spec.HostsTemplate is
+// unset; kept only for callers that still address a tenant as a single
+// flat StatefulSet.
+func (mi *MinIOInstance) MinIOHosts() []string {
+	var hosts []string
+	var replicas = mi.MinIOReplicas()
+	for i := int32(0); i < replicas; i++ {
+		hosts = append(hosts, fmt.Sprintf("%s-%d.%s.%s.svc.cluster.local", mi.MinIOStatefulSetName(), i, mi.MinIOStatefulSetName(), mi.Namespace))
+	}
+	return hosts
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright (C) 2020, MinIO, Inc.
+ *
+ * This code is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License, version 3,
+ * as published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License, version 3,
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>
+ *
+ */
+
+// Package controller holds the tenant reconciliation logic that's
+// independent of how resources are actually fetched from or applied to the
+// API server, so it can be unit tested against plain structs.
+package controller
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+
+	miniov1 "github.com/minio/minio-operator/pkg/apis/operator.min.io/v1"
+)
+
+// ZoneStatus pairs a zone with the StatefulSet the controller has last
+// observed for it. StatefulSet is nil when the zone hasn't been created
+// yet.
+type ZoneStatus struct {
+	Zone        miniov1.Zone
+	StatefulSet *appsv1.StatefulSet
+}
+
+// zoneIsCurrent reports whether a zone's StatefulSet has finished rolling
+// out: the desired replica count has actually been applied to the Spec (not
+// just reflected in a possibly-stale Status), and every replica is on the
+// current revision and Ready.
+func zoneIsCurrent(zs ZoneStatus) bool {
+	ss := zs.StatefulSet
+	if ss == nil {
+		return false
+	}
+	desired := int32(zs.Zone.Servers)
+	if ss.Spec.Replicas == nil || *ss.Spec.Replicas != desired {
+		return false
+	}
+	if ss.Status.CurrentRevision != ss.Status.UpdateRevision {
+		return false
+	}
+	return ss.Status.Replicas == desired && ss.Status.ReadyReplicas == ss.Status.Replicas
+}
+
+// NextZonesToRoll returns, in zone order, the names of the zones the
+// reconciler should create or update on this pass. A zone already
+// mid-rollout is always carried through to completion before any new zone
+// is started; beyond that, not-yet-current zones are added one at a time
+// until mi.MaxUnavailableZones() zones are rolling concurrently. This keeps
+// a tenant-wide update (or the initial creation of a multi-zone tenant)
+// from taking down more zones at once than the tenant opted into. A zone
+// counts as mid-rollout once its StatefulSet exists and its Spec.Replicas
+// isn't stale. Whether a zone is current is judged only by zoneIsCurrent;
+// other changes to the desired pod spec (such as a new image) that haven't
+// been applied to a zone's StatefulSet don't make that zone count as not
+// current here.
+func NextZonesToRoll(mi *miniov1.MinIOInstance, zones []ZoneStatus) []string {
+	budget := mi.MaxUnavailableZones()
+
+	var rolling, pending []string
+	for _, zs := range zones {
+		if zoneIsCurrent(zs) {
+			continue
+		}
+		ss := zs.StatefulSet
+		if ss == nil || (ss.Spec.Replicas != nil && *ss.Spec.Replicas != int32(zs.Zone.Servers)) {
+			pending = append(pending, zs.Zone.Name)
+			continue
+		}
+		rolling = append(rolling, zs.Zone.Name)
+	}
+
+	result := append([]string{}, rolling...)
+	for _, name := range pending {
+		if len(result) >= budget {
+			break
+		}
+		result = append(result, name)
+	}
+	return result
+}
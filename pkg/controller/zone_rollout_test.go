@@ -0,0 +1,119 @@
+/*
+ * Copyright (C) 2020, MinIO, Inc.
+ *
+ * This code is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License, version 3,
+ * as published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License, version 3,
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>
+ *
+ */
+
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	miniov1 "github.com/minio/minio-operator/pkg/apis/operator.min.io/v1"
+)
+
+func currentStatefulSet(replicas int32) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+		},
+		Status: appsv1.StatefulSetStatus{
+			Replicas:        replicas,
+			ReadyReplicas:   replicas,
+			CurrentRevision: "rev-1",
+			UpdateRevision:  "rev-1",
+		},
+	}
+}
+
+func TestNextZonesToRollDefaultsToOneAtATime(t *testing.T) {
+	mi := &miniov1.MinIOInstance{}
+	zones := []ZoneStatus{
+		{Zone: miniov1.Zone{Name: "zone-0", Servers: 2}, StatefulSet: nil},
+		{Zone: miniov1.Zone{Name: "zone-1", Servers: 2}, StatefulSet: nil},
+	}
+
+	got := NextZonesToRoll(mi, zones)
+	want := []string{"zone-0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("NextZonesToRoll() = %v, want %v", got, want)
+	}
+}
+
+func TestNextZonesToRollFinishesRollingZonesFirst(t *testing.T) {
+	mi := &miniov1.MinIOInstance{Spec: miniov1.MinIOInstanceSpec{MaxUnavailableZones: 1}}
+	zones := []ZoneStatus{
+		{Zone: miniov1.Zone{Name: "zone-0", Servers: 2}, StatefulSet: &appsv1.StatefulSet{
+			Status: appsv1.StatefulSetStatus{Replicas: 2, ReadyReplicas: 1, CurrentRevision: "rev-1", UpdateRevision: "rev-2"},
+		}},
+		{Zone: miniov1.Zone{Name: "zone-1", Servers: 2}, StatefulSet: nil},
+	}
+
+	got := NextZonesToRoll(mi, zones)
+	want := []string{"zone-0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("NextZonesToRoll() = %v, want %v", got, want)
+	}
+}
+
+func TestNextZonesToRollRespectsHigherBudget(t *testing.T) {
+	mi := &miniov1.MinIOInstance{Spec: miniov1.MinIOInstanceSpec{MaxUnavailableZones: 2}}
+	zones := []ZoneStatus{
+		{Zone: miniov1.Zone{Name: "zone-0", Servers: 2}, StatefulSet: nil},
+		{Zone: miniov1.Zone{Name: "zone-1", Servers: 2}, StatefulSet: nil},
+		{Zone: miniov1.Zone{Name: "zone-2", Servers: 2}, StatefulSet: nil},
+	}
+
+	got := NextZonesToRoll(mi, zones)
+	want := []string{"zone-0", "zone-1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("NextZonesToRoll() = %v, want %v", got, want)
+	}
+}
+
+func TestNextZonesToRollSkipsCurrentZones(t *testing.T) {
+	mi := &miniov1.MinIOInstance{}
+	zones := []ZoneStatus{
+		{Zone: miniov1.Zone{Name: "zone-0", Servers: 2}, StatefulSet: currentStatefulSet(2)},
+		{Zone: miniov1.Zone{Name: "zone-1", Servers: 2}, StatefulSet: nil},
+	}
+
+	got := NextZonesToRoll(mi, zones)
+	want := []string{"zone-1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("NextZonesToRoll() = %v, want %v", got, want)
+	}
+}
+
+func TestNextZonesToRollTreatsStaleSpecReplicasAsNotCurrent(t *testing.T) {
+	mi := &miniov1.MinIOInstance{}
+	oldReplicas := int32(2)
+	zones := []ZoneStatus{
+		{Zone: miniov1.Zone{Name: "zone-0", Servers: 4}, StatefulSet: &appsv1.StatefulSet{
+			Spec: appsv1.StatefulSetSpec{Replicas: &oldReplicas},
+			Status: appsv1.StatefulSetStatus{
+				Replicas: 4, ReadyReplicas: 4, CurrentRevision: "rev-1", UpdateRevision: "rev-1",
+			},
+		}},
+	}
+
+	got := NextZonesToRoll(mi, zones)
+	want := []string{"zone-0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("NextZonesToRoll() = %v, want %v", got, want)
+	}
+}
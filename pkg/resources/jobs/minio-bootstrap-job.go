@@ -0,0 +1,260 @@
+/*
+ * Copyright (C) 2020, MinIO, Inc.
+ *
+ * This code is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License, version 3,
+ * as published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License, version 3,
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>
+ *
+ */
+
+package jobs
+
+import (
+	"fmt"
+	"strings"
+
+	miniov1 "github.com/minio/minio-operator/pkg/apis/operator.min.io/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	bootstrapJobContainerName = "mc-bootstrap"
+	// bootstrapAlias is the `mc` alias the bootstrap script configures
+	// for the tenant's own MinIO service.
+	bootstrapAlias        = "bootstrap"
+	bootstrapUsersPath    = "/tmp/mc-users"
+	bootstrapPoliciesPath = "/tmp/mc-policies"
+)
+
+// bootstrapBackoffLimit caps how many times the Job controller retries the
+// bootstrap Pod before giving up; every `mc` call the script makes is
+// idempotent, so a retry after a transient failure is always safe.
+var bootstrapBackoffLimit = int32(6)
+
+// NewBootstrapJobForMinIO builds the Job that applies Spec.Buckets,
+// Spec.Users, and Spec.Policies via `mc` once the tenant is reachable. It
+// runs as a standalone Job rather than a StatefulSet init container: an
+// init container always runs to completion before the pod's own MinIO
+// container starts, so on a fresh install the Service behind serviceName
+// has no ready endpoints yet and a script that waits on
+// /minio/health/ready would never return. A Job polls that same endpoint
+// from outside the StatefulSet's own startup path, so it only proceeds
+// once a server is actually answering.
+// It returns nil when the tenant hasn't opted into bootstrap, and an error
+// if a declared user or policy is missing the Secret/ConfigMap reference
+// its volume mount depends on.
+func NewBootstrapJobForMinIO(mi *miniov1.MinIOInstance, serviceName string) (*batchv1.Job, error) {
+	if !mi.HasBootstrapEnabled() {
+		return nil, nil
+	}
+	if err := validateBootstrapRefs(mi); err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("http://%s:%d", serviceName, miniov1.MinIOPort)
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: mi.Namespace,
+			Name:      mi.MinIOStatefulSetName() + "-bootstrap",
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(mi, schema.GroupVersionKind{
+					Group:   miniov1.SchemeGroupVersion.Group,
+					Version: miniov1.SchemeGroupVersion.Version,
+					Kind:    miniov1.MinIOCRDResourceKind,
+				}),
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &bootstrapBackoffLimit,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyOnFailure,
+					Containers: []corev1.Container{
+						{
+							Name:            bootstrapJobContainerName,
+							Image:           mi.Spec.Bootstrap.Image,
+							ImagePullPolicy: miniov1.DefaultImagePullPolicy,
+							Command:         []string{"/bin/sh", "-c"},
+							Args:            []string{bootstrapScript(mi, endpoint)},
+							Env:             bootstrapEnvironmentVars(mi),
+							VolumeMounts:    bootstrapVolumeMounts(mi),
+						},
+					},
+					Volumes: bootstrapVolumes(mi),
+				},
+			},
+		},
+	}, nil
+}
+
+// validateBootstrapRefs checks the Secret/ConfigMap references that
+// bootstrapVolumes needs before building any volumes from them, so a
+// misconfigured tenant gets a reconcile error instead of the operator
+// panicking on a nil CredentialsSecret/ConfigMap.
+func validateBootstrapRefs(mi *miniov1.MinIOInstance) error {
+	for _, user := range mi.Spec.Users {
+		if user.CredentialsSecret == nil {
+			return fmt.Errorf("user %q: credentialsSecret must be set", user.Name)
+		}
+	}
+	for _, policy := range mi.Spec.Policies {
+		if policy.ConfigMap == nil {
+			return fmt.Errorf("policy %q: configMap must be set", policy.Name)
+		}
+	}
+	return nil
+}
+
+// bootstrapEnvironmentVars sources the alias credentials from
+// Spec.CredsSecret, the same Secret the MinIO container itself uses, so the
+// bootstrap script never needs its own copy of the root credentials.
+func bootstrapEnvironmentVars(mi *miniov1.MinIOInstance) []corev1.EnvVar {
+	if !mi.HasCredsSecret() {
+		return nil
+	}
+	secretName := mi.Spec.CredsSecret.Name
+	return []corev1.EnvVar{
+		secretEnvVar("MINIO_ACCESS_KEY", secretName, "accesskey"),
+		secretEnvVar("MINIO_SECRET_KEY", secretName, "secretkey"),
+	}
+}
+
+func secretEnvVar(name, secretName, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		},
+	}
+}
+
+// bootstrapScript renders the shell script run by the bootstrap Job.
+// Policies are created before users: with `set -e`, a user that's assigned
+// a policy which doesn't exist yet would abort the whole script on its
+// first failed `mc admin policy set`.
+func bootstrapScript(mi *miniov1.MinIOInstance, endpoint string) string {
+	var script strings.Builder
+	script.WriteString("set -e\n")
+	script.WriteString("if [ -z \"$MINIO_ACCESS_KEY\" ] || [ -z \"$MINIO_SECRET_KEY\" ]; then echo 'mc-bootstrap requires spec.credsSecret to be set' >&2; exit 1; fi\n")
+	fmt.Fprintf(&script, "until curl -sf %s/minio/health/ready; do sleep 1; done\n", endpoint)
+	fmt.Fprintf(&script, "mc alias set %s %s \"$MINIO_ACCESS_KEY\" \"$MINIO_SECRET_KEY\"\n", bootstrapAlias, endpoint)
+
+	for _, bucket := range mi.Spec.Buckets {
+		target := bootstrapAlias + "/" + bucket.Name
+		if bucket.ObjectLocking.Mode != "" {
+			// Object locking can only be turned on at bucket creation, and
+			// requires versioning, so --with-lock has to be part of `mc mb`
+			// itself rather than a follow-up `mc retention set` against an
+			// unlocked bucket, which would exit non-zero under `set -e`.
+			fmt.Fprintf(&script, "mc mb -p --with-lock %s\n", target)
+		} else {
+			fmt.Fprintf(&script, "mc mb -p %s\n", target)
+		}
+		if bucket.Versioning {
+			fmt.Fprintf(&script, "mc version enable %s\n", target)
+		}
+		if bucket.ObjectLocking.Mode != "" {
+			fmt.Fprintf(&script, "mc retention set --default %s %dd %s\n", bucket.ObjectLocking.Mode, bucket.ObjectLocking.ValidityDays, target)
+		}
+		if bucket.Quota != "" {
+			fmt.Fprintf(&script, "mc quota set %s --hard %s\n", target, bucket.Quota)
+		}
+		for i, rule := range bucket.LifecycleRules {
+			ruleID := fmt.Sprintf("%s-rule-%d", bucket.Name, i)
+			// mc ilm add isn't idempotent, so drop any rule we previously
+			// added under this deterministic ID before re-adding it.
+			fmt.Fprintf(&script, "mc ilm rm --id %s %s 2>/dev/null || true\n", ruleID, target)
+			fmt.Fprintf(&script, "mc ilm add --id %s --expiry-days %d %s\n", ruleID, rule.ExpiryDays, target)
+		}
+	}
+
+	for _, policy := range mi.Spec.Policies {
+		fmt.Fprintf(&script, "mc admin policy add %s %s %s/%s.json\n", bootstrapAlias, policy.Name, bootstrapPoliciesPath, policy.Name)
+	}
+
+	for _, user := range mi.Spec.Users {
+		userDir := bootstrapUsersPath + "/" + user.Name
+		fmt.Fprintf(&script, "mc admin user add %s \"$(cat %s/accesskey)\" \"$(cat %s/secretkey)\"\n", bootstrapAlias, userDir, userDir)
+		if len(user.Policies) > 0 {
+			// mc admin policy set replaces the user's whole policy set on
+			// each call, so every policy must be attached in a single
+			// comma-joined call rather than one call per policy, or only
+			// the last policy in the loop would stick.
+			fmt.Fprintf(&script, "mc admin policy set %s %s user=\"$(cat %s/accesskey)\"\n", bootstrapAlias, strings.Join(user.Policies, ","), userDir)
+		}
+	}
+
+	return script.String()
+}
+
+// bootstrapVolumeMounts mounts each declared user's credentials Secret and
+// the combined policy documents projected volume into the bootstrap
+// container.
+func bootstrapVolumeMounts(mi *miniov1.MinIOInstance) []corev1.VolumeMount {
+	var mounts []corev1.VolumeMount
+	for _, user := range mi.Spec.Users {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      "mc-user-" + user.Name,
+			MountPath: bootstrapUsersPath + "/" + user.Name,
+			ReadOnly:  true,
+		})
+	}
+	if len(mi.Spec.Policies) > 0 {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      "mc-policies",
+			MountPath: bootstrapPoliciesPath,
+			ReadOnly:  true,
+		})
+	}
+	return mounts
+}
+
+// bootstrapVolumes builds the pod volumes backing bootstrapVolumeMounts:
+// one Secret volume per declared user, and a single projected volume
+// gathering every policy document into bootstrapPoliciesPath.
+func bootstrapVolumes(mi *miniov1.MinIOInstance) []corev1.Volume {
+	var volumes []corev1.Volume
+	for _, user := range mi.Spec.Users {
+		volumes = append(volumes, corev1.Volume{
+			Name: "mc-user-" + user.Name,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: user.CredentialsSecret.Name},
+			},
+		})
+	}
+	if len(mi.Spec.Policies) > 0 {
+		var sources []corev1.VolumeProjection
+		for _, policy := range mi.Spec.Policies {
+			sources = append(sources, corev1.VolumeProjection{
+				ConfigMap: &corev1.ConfigMapProjection{
+					LocalObjectReference: corev1.LocalObjectReference{Name: policy.ConfigMap.Name},
+					Items: []corev1.KeyToPath{
+						{Key: "policy.json", Path: policy.Name + ".json"},
+					},
+				},
+			})
+		}
+		volumes = append(volumes, corev1.Volume{
+			Name: "mc-policies",
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{Sources: sources},
+			},
+		})
+	}
+	return volumes
+}
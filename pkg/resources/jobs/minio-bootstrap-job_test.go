@@ -0,0 +1,115 @@
+/*
+ * Copyright (C) 2020, MinIO, Inc.
+ *
+ * This code is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License, version 3,
+ * as published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License, version 3,
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>
+ *
+ */
+
+package jobs
+
+import (
+	"strings"
+	"testing"
+
+	miniov1 "github.com/minio/minio-operator/pkg/apis/operator.min.io/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestBootstrapScriptCreatesPoliciesBeforeUsers(t *testing.T) {
+	mi := &miniov1.MinIOInstance{
+		Spec: miniov1.MinIOInstanceSpec{
+			Policies: []miniov1.PolicySpec{
+				{Name: "readonly", ConfigMap: &corev1.LocalObjectReference{Name: "readonly-cm"}},
+			},
+			Users: []miniov1.UserSpec{
+				{
+					Name:              "alice",
+					CredentialsSecret: &corev1.LocalObjectReference{Name: "alice-creds"},
+					Policies:          []string{"readonly", "readwrite"},
+				},
+			},
+		},
+	}
+
+	script := bootstrapScript(mi, "http://svc:9000")
+
+	policyIdx := strings.Index(script, "mc admin policy add bootstrap readonly")
+	userIdx := strings.Index(script, "mc admin user add bootstrap")
+	if policyIdx == -1 || userIdx == -1 {
+		t.Fatalf("expected both a policy add and a user add in script, got:\n%s", script)
+	}
+	if policyIdx > userIdx {
+		t.Fatalf("policy creation must come before user creation, got:\n%s", script)
+	}
+
+	want := `mc admin policy set bootstrap readonly,readwrite user="$(cat /tmp/mc-users/alice/accesskey)"`
+	if !strings.Contains(script, want) {
+		t.Fatalf("expected a single comma-joined policy set call %q, got:\n%s", want, script)
+	}
+	if strings.Count(script, "mc admin policy set") != 1 {
+		t.Fatalf("expected exactly one policy set call per user, got:\n%s", script)
+	}
+}
+
+func TestBootstrapScriptEnablesLockAtBucketCreation(t *testing.T) {
+	mi := &miniov1.MinIOInstance{
+		Spec: miniov1.MinIOInstanceSpec{
+			Buckets: []miniov1.BucketSpec{
+				{
+					Name:          "audit",
+					ObjectLocking: miniov1.ObjectLockingSpec{Mode: "compliance", ValidityDays: 30},
+				},
+			},
+		},
+	}
+
+	script := bootstrapScript(mi, "http://svc:9000")
+
+	if !strings.Contains(script, "mc mb -p --with-lock bootstrap/audit") {
+		t.Fatalf("expected bucket creation to pass --with-lock, got:\n%s", script)
+	}
+	if strings.Contains(script, "mc mb -p bootstrap/audit\n") {
+		t.Fatalf("bucket should not be created without --with-lock when object locking is set, got:\n%s", script)
+	}
+
+	mbIdx := strings.Index(script, "mc mb -p --with-lock bootstrap/audit")
+	retentionIdx := strings.Index(script, "mc retention set --default compliance 30d bootstrap/audit")
+	if mbIdx == -1 || retentionIdx == -1 || mbIdx > retentionIdx {
+		t.Fatalf("expected bucket creation before retention set, got:\n%s", script)
+	}
+}
+
+func TestNewBootstrapJobForMinIONilWhenDisabled(t *testing.T) {
+	mi := &miniov1.MinIOInstance{}
+	job, err := NewBootstrapJobForMinIO(mi, "svc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job != nil {
+		t.Fatalf("expected nil Job when bootstrap isn't enabled, got %+v", job)
+	}
+}
+
+func TestNewBootstrapJobForMinIORejectsMissingCredentialsSecret(t *testing.T) {
+	mi := &miniov1.MinIOInstance{
+		Spec: miniov1.MinIOInstanceSpec{
+			Bootstrap: &miniov1.BootstrapSpec{Image: "minio/mc"},
+			Users: []miniov1.UserSpec{
+				{Name: "alice"},
+			},
+		},
+	}
+	if _, err := NewBootstrapJobForMinIO(mi, "svc"); err == nil {
+		t.Fatal("expected an error for a user with no credentialsSecret")
+	}
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright (C) 2020, MinIO, Inc.
+ *
+ * This code is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License, version 3,
+ * as published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License, version 3,
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>
+ *
+ */
+
+package poddisruptionbudgets
+
+import (
+	"fmt"
+
+	miniov1 "github.com/minio/minio-operator/pkg/apis/operator.min.io/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// NewForZone builds the PodDisruptionBudget for a single zone, so rolling
+// updates and voluntary evictions can be staged zone-by-zone across large
+// multi-zone tenants instead of being bound by one tenant-wide budget. It
+// returns nil when the zone doesn't declare a PDB, and an error when the
+// PDB sets both MinAvailable and MaxUnavailable (or neither) — the
+// Kubernetes API server rejects a PodDisruptionBudgetSpec unless exactly
+// one is set, and it's better to catch that here than from a failed apply.
+func NewForZone(mi *miniov1.MinIOInstance, zone *miniov1.Zone) (*policyv1beta1.PodDisruptionBudget, error) {
+	if zone.PDB == nil {
+		return nil, nil
+	}
+	if (zone.PDB.MinAvailable == nil) == (zone.PDB.MaxUnavailable == nil) {
+		return nil, fmt.Errorf("zone %q: pdb must set exactly one of minAvailable or maxUnavailable", zone.Name)
+	}
+
+	return &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: mi.Namespace,
+			Name:      mi.MinIOStatefulSetName() + "-" + zone.Name,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(mi, schema.GroupVersionKind{
+					Group:   miniov1.SchemeGroupVersion.Group,
+					Version: miniov1.SchemeGroupVersion.Version,
+					Kind:    miniov1.MinIOCRDResourceKind,
+				}),
+			},
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			MinAvailable:   zone.PDB.MinAvailable,
+			MaxUnavailable: zone.PDB.MaxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					miniov1.InstanceLabel: mi.MinIOStatefulSetName(),
+					miniov1.ZoneLabel:     zone.Name,
+				},
+			},
+		},
+	}, nil
+}
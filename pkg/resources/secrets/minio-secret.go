@@ -0,0 +1,57 @@
+/*
+ * Copyright (C) 2020, MinIO, Inc.
+ *
+ * This code is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License, version 3,
+ * as published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License, version 3,
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>
+ *
+ */
+
+package secrets
+
+import (
+	miniov1 "github.com/minio/minio-operator/pkg/apis/operator.min.io/v1"
+	"github.com/minio/minio-operator/pkg/resources/statefulsets"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// NewTLSSecretForMinIO wraps an operator-generated public/private key pair
+// in the Secret backing mi.MinIOTLSSecretName(), under the
+// "public.crt"/"private.key" keys NewForMinIO's projected TLS volume
+// reads when Spec.RequestAutoCert is in use. An external secret of type
+// "kubernetes.io/tls" or "cert-manager.io/v1alpha2" is read under
+// different keys.
+func NewTLSSecretForMinIO(mi *miniov1.MinIOInstance, publicCert, privateKey []byte) *corev1.Secret {
+	meta := statefulsets.InheritMeta(mi, nil)
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   mi.Namespace,
+			Name:        mi.MinIOTLSSecretName(),
+			Labels:      meta.Labels,
+			Annotations: meta.Annotations,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(mi, schema.GroupVersionKind{
+					Group:   miniov1.SchemeGroupVersion.Group,
+					Version: miniov1.SchemeGroupVersion.Version,
+					Kind:    miniov1.MinIOCRDResourceKind,
+				}),
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"public.crt":  publicCert,
+			"private.key": privateKey,
+		},
+	}
+}
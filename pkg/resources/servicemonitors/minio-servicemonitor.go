@@ -0,0 +1,100 @@
+/*
+ * Copyright (C) 2020, MinIO, Inc.
+ *
+ * This code is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License, version 3,
+ * as published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License, version 3,
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>
+ *
+ */
+
+// Package servicemonitors builds the Prometheus Operator ServiceMonitor
+// that lets Prometheus discover and scrape a tenant's metrics endpoint.
+// Callers must check IsAvailable before creating the object returned by
+// NewForMinIO: the Prometheus Operator CRDs are optional, and applying a
+// ServiceMonitor to a cluster that doesn't have them registered fails.
+package servicemonitors
+
+import (
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	miniov1 "github.com/minio/minio-operator/pkg/apis/operator.min.io/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// servicemonitorGroupVersion is the Prometheus Operator API group/version
+// IsAvailable checks for.
+const servicemonitorGroupVersion = "monitoring.coreos.com/v1"
+
+// portName is the name given to the MinIO Service port the ServiceMonitor
+// targets; it must match the name on the Service NewForMinIO's endpoint
+// selects.
+const portName = "minio"
+
+// IsAvailable reports whether the Prometheus Operator CRDs, including
+// ServiceMonitor, are registered on the cluster.
+func IsAvailable(disco discovery.DiscoveryInterface) (bool, error) {
+	resources, err := disco.ServerResourcesForGroupVersion(servicemonitorGroupVersion)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	for _, r := range resources.APIResources {
+		if r.Kind == "ServiceMonitor" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// NewForMinIO builds the ServiceMonitor scraping serviceName's metrics
+// endpoint for mi. It returns nil when the tenant has no monitoring
+// configured; the caller is still responsible for checking IsAvailable
+// before applying the result.
+func NewForMinIO(mi *miniov1.MinIOInstance, serviceName string) *monitoringv1.ServiceMonitor {
+	if !mi.HasMonitoringEnabled() {
+		return nil
+	}
+
+	return &monitoringv1.ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: mi.Namespace,
+			Name:      mi.MinIOStatefulSetName(),
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(mi, schema.GroupVersionKind{
+					Group:   miniov1.SchemeGroupVersion.Group,
+					Version: miniov1.SchemeGroupVersion.Version,
+					Kind:    miniov1.MinIOCRDResourceKind,
+				}),
+			},
+		},
+		Spec: monitoringv1.ServiceMonitorSpec{
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					miniov1.InstanceLabel: mi.MinIOStatefulSetName(),
+				},
+			},
+			NamespaceSelector: monitoringv1.NamespaceSelector{
+				MatchNames: []string{mi.Namespace},
+			},
+			Endpoints: []monitoringv1.Endpoint{
+				{
+					Port:     portName,
+					Path:     miniov1.PrometheusMetricsPath,
+					Interval: "30s",
+				},
+			},
+		},
+	}
+}
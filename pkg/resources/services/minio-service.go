@@ -0,0 +1,62 @@
+/*
+ * Copyright (C) 2020, MinIO, Inc.
+ *
+ * This code is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License, version 3,
+ * as published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License, version 3,
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>
+ *
+ */
+
+package services
+
+import (
+	miniov1 "github.com/minio/minio-operator/pkg/apis/operator.min.io/v1"
+	"github.com/minio/minio-operator/pkg/resources/statefulsets"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// NewHeadlessForMinIO builds the headless Service every zone's StatefulSet
+// pods register under. PublishNotReadyAddresses is set because the MinIO
+// `server` args resolve every peer up front, before any of them are Ready;
+// without it, a fresh tenant's pods would never see each other's DNS
+// records and the cluster could never form in the first place.
+func NewHeadlessForMinIO(mi *miniov1.MinIOInstance) *corev1.Service {
+	meta := statefulsets.InheritMeta(mi, mi.MinIOPodLabels())
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   mi.Namespace,
+			Name:        mi.MinIOStatefulSetName(),
+			Labels:      meta.Labels,
+			Annotations: meta.Annotations,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(mi, schema.GroupVersionKind{
+					Group:   miniov1.SchemeGroupVersion.Group,
+					Version: miniov1.SchemeGroupVersion.Version,
+					Kind:    miniov1.MinIOCRDResourceKind,
+				}),
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP:                corev1.ClusterIPNone,
+			PublishNotReadyAddresses: true,
+			Selector:                 mi.MinIOPodLabels(),
+			Ports: []corev1.ServicePort{
+				{
+					Name: miniov1.MinIOServerName,
+					Port: miniov1.MinIOPort,
+				},
+			},
+		},
+	}
+}
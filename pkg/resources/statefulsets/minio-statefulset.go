@@ -18,9 +18,11 @@
 package statefulsets
 
 import (
+	"bytes"
 	"fmt"
 	"net"
 	"strconv"
+	"text/template"
 
 	miniov1 "github.com/minio/minio-operator/pkg/apis/operator.min.io/v1"
 	appsv1 "k8s.io/api/apps/v1"
@@ -29,6 +31,153 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
+// defaultClusterDomain is used as the ClusterDomain template field when the
+// MinIOInstance doesn't otherwise carry one, matching the suffix baked into
+// the default CoreDNS-style hostnames returned by mi.MinIOHosts().
+const defaultClusterDomain = "cluster.local"
+
+// monitoringSidecarName is the container name used for the optional
+// Prometheus scraping sidecar.
+const monitoringSidecarName = "monitoring-sidecar"
+
+// minioHostsTemplateData is the context made available to
+// Spec.HostsTemplate when rendering each peer hostname. StatefulSet is the
+// tenant-wide base name; since NewForMinIO names each zone's StatefulSet
+// "{{.StatefulSet}}-{{.Zone}}", a template reconstructing real pod DNS
+// names must include Zone, not just StatefulSet and Ordinal.
+type minioHostsTemplateData struct {
+	StatefulSet   string
+	Zone          string
+	Service       string
+	Namespace     string
+	ClusterDomain string
+	// Index is the replica's position across the whole tenant.
+	Index int
+	// ZoneIndex is the position of the zone the replica belongs to.
+	ZoneIndex int
+	// Ordinal is the replica's position within its zone.
+	Ordinal int
+}
+
+// secretEnvVar builds an EnvVar sourced from a Secret key, the pattern used
+// throughout this file for credentials that must never be inlined as plain
+// values.
+func secretEnvVar(name, secretName, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secretName,
+				},
+				Key: key,
+			},
+		},
+	}
+}
+
+// kmsProvider identifies which backend minioEnvironmentVars wires up for
+// MinIO's server-side encryption.
+type kmsProvider string
+
+const (
+	kmsProviderKES   kmsProvider = "kes"
+	kmsProviderVault kmsProvider = "vault"
+	kmsProviderAWS   kmsProvider = "aws"
+	kmsProviderGCP   kmsProvider = "gcp"
+)
+
+// activeKMSProvider returns the configured KMS backend, if any. Spec.KMS.Provider
+// is authoritative; for backwards compatibility a tenant that only sets the
+// legacy Spec.KES block and never touches Spec.KMS is still treated as "kes".
+func activeKMSProvider(mi *miniov1.MinIOInstance) kmsProvider {
+	if mi.Spec.KMS != nil && mi.Spec.KMS.Provider != "" {
+		return kmsProvider(mi.Spec.KMS.Provider)
+	}
+	if mi.HasKESEnabled() {
+		return kmsProviderKES
+	}
+	return ""
+}
+
+// kesEnvironmentVars returns the MINIO_KMS_KES_* env vars. The client
+// certificate and CA referenced here are mounted from the projected TLS
+// volume, which NewForMinIO only attaches when auto or external TLS
+// certificates are set up, and which only carries them when
+// activeKMSProvider(mi) == kmsProviderKES.
+func kesEnvironmentVars(mi *miniov1.MinIOInstance) []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{Name: "MINIO_KMS_KES_ENDPOINT", Value: "https://" + net.JoinHostPort(mi.KESServiceHost(), strconv.Itoa(miniov1.KESPort))},
+		{Name: "MINIO_KMS_KES_CERT_FILE", Value: "/root/.minio/certs/client.crt"},
+		{Name: "MINIO_KMS_KES_KEY_FILE", Value: "/root/.minio/certs/client.key"},
+		{Name: "MINIO_KMS_KES_CA_PATH", Value: "/root/.minio/certs/CAs/server.crt"},
+		{Name: "MINIO_KMS_KES_KEY_NAME", Value: miniov1.KESMinIOKey},
+	}
+}
+
+// vaultEnvironmentVars returns the MINIO_KMS_VAULT_* env vars for
+// Spec.KMS.Vault, sourcing the AppRole credentials from a user Secret rather
+// than inlining them.
+func vaultEnvironmentVars(mi *miniov1.MinIOInstance) []corev1.EnvVar {
+	vault := mi.Spec.KMS.Vault
+	if vault == nil {
+		return nil
+	}
+	envVars := []corev1.EnvVar{
+		{Name: "MINIO_KMS_VAULT_ENDPOINT", Value: vault.Endpoint},
+		{Name: "MINIO_KMS_VAULT_KEY_NAME", Value: vault.KeyName},
+	}
+	if vault.Namespace != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: "MINIO_KMS_VAULT_NAMESPACE", Value: vault.Namespace})
+	}
+	if vault.KeyVersion != 0 {
+		envVars = append(envVars, corev1.EnvVar{Name: "MINIO_KMS_VAULT_KEY_VERSION", Value: strconv.Itoa(vault.KeyVersion)})
+	}
+	if vault.AppRoleSecret != nil {
+		envVars = append(envVars,
+			secretEnvVar("MINIO_KMS_VAULT_APPROLE_ID", vault.AppRoleSecret.Name, "roleid"),
+			secretEnvVar("MINIO_KMS_VAULT_APPROLE_SECRET", vault.AppRoleSecret.Name, "secretid"),
+		)
+	}
+	return envVars
+}
+
+// awsKMSEnvironmentVars returns the MINIO_KMS_AWS_* env vars for Spec.KMS.AWS.
+func awsKMSEnvironmentVars(mi *miniov1.MinIOInstance) []corev1.EnvVar {
+	aws := mi.Spec.KMS.AWS
+	if aws == nil {
+		return nil
+	}
+	envVars := []corev1.EnvVar{
+		{Name: "MINIO_KMS_AWS_REGION", Value: aws.Region},
+		{Name: "MINIO_KMS_AWS_KMS_KEY", Value: aws.KeyID},
+	}
+	if aws.CredentialsSecret != nil {
+		envVars = append(envVars,
+			secretEnvVar("MINIO_KMS_AWS_ACCESS_KEY", aws.CredentialsSecret.Name, "accesskey"),
+			secretEnvVar("MINIO_KMS_AWS_SECRET_KEY", aws.CredentialsSecret.Name, "secretkey"),
+		)
+	}
+	return envVars
+}
+
+// gcpKMSEnvironmentVars returns the MINIO_KMS_GCP_* env vars for Spec.KMS.GCP.
+func gcpKMSEnvironmentVars(mi *miniov1.MinIOInstance) []corev1.EnvVar {
+	gcp := mi.Spec.KMS.GCP
+	if gcp == nil {
+		return nil
+	}
+	envVars := []corev1.EnvVar{
+		{Name: "MINIO_KMS_GCP_PROJECT_ID", Value: gcp.ProjectID},
+		{Name: "MINIO_KMS_GCP_KEY_RING", Value: gcp.KeyRing},
+		{Name: "MINIO_KMS_GCP_KEY_NAME", Value: gcp.KeyName},
+	}
+	if gcp.CredentialsSecret != nil {
+		envVars = append(envVars, secretEnvVar("MINIO_KMS_GCP_CREDENTIALS", gcp.CredentialsSecret.Name, "credentials.json"))
+	}
+	return envVars
+}
+
 // Returns the MinIO environment variables set in configuration.
 // If a user specifies a secret in the spec (for MinIO credentials) we use
 // that to set MINIO_ACCESS_KEY & MINIO_SECRET_KEY.
@@ -41,46 +190,29 @@ func minioEnvironmentVars(mi *miniov1.MinIOInstance) []corev1.EnvVar {
 	// Add env variables from credentials secret, if no secret provided, dont use
 	// env vars. MinIO server automatically creates default credentials
 	if mi.HasCredsSecret() {
-		var secretName string
-		secretName = mi.Spec.CredsSecret.Name
-		envVars = append(envVars, corev1.EnvVar{
-			Name: "MINIO_ACCESS_KEY",
-			ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: secretName,
-					},
-					Key: "accesskey",
-				},
-			},
-		}, corev1.EnvVar{
-			Name: "MINIO_SECRET_KEY",
-			ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: secretName,
-					},
-					Key: "secretkey",
-				},
-			},
-		})
+		secretName := mi.Spec.CredsSecret.Name
+		envVars = append(envVars,
+			secretEnvVar("MINIO_ACCESS_KEY", secretName, "accesskey"),
+			secretEnvVar("MINIO_SECRET_KEY", secretName, "secretkey"),
+		)
 	}
-	if mi.HasKESEnabled() {
+	switch activeKMSProvider(mi) {
+	case kmsProviderKES:
+		envVars = append(envVars, kesEnvironmentVars(mi)...)
+	case kmsProviderVault:
+		envVars = append(envVars, vaultEnvironmentVars(mi)...)
+	case kmsProviderAWS:
+		envVars = append(envVars, awsKMSEnvironmentVars(mi)...)
+	case kmsProviderGCP:
+		envVars = append(envVars, gcpKMSEnvironmentVars(mi)...)
+	}
+	if mi.HasMonitoringEnabled() {
 		envVars = append(envVars, corev1.EnvVar{
-			Name:  "MINIO_KMS_KES_ENDPOINT",
-			Value: "https://" + net.JoinHostPort(mi.KESServiceHost(), strconv.Itoa(miniov1.KESPort)),
-		}, corev1.EnvVar{
-			Name:  "MINIO_KMS_KES_CERT_FILE",
-			Value: "/root/.minio/certs/client.crt",
-		}, corev1.EnvVar{
-			Name:  "MINIO_KMS_KES_KEY_FILE",
-			Value: "/root/.minio/certs/client.key",
-		}, corev1.EnvVar{
-			Name:  "MINIO_KMS_KES_CA_PATH",
-			Value: "/root/.minio/certs/CAs/server.crt",
+			Name:  "MINIO_PROMETHEUS_AUTH_TYPE",
+			Value: "public",
 		}, corev1.EnvVar{
-			Name:  "MINIO_KMS_KES_KEY_NAME",
-			Value: miniov1.KESMinIOKey,
+			Name:  "MINIO_PROMETHEUS_URL",
+			Value: mi.Spec.Monitoring.PrometheusURL,
 		})
 	}
 
@@ -88,31 +220,68 @@ func minioEnvironmentVars(mi *miniov1.MinIOInstance) []corev1.EnvVar {
 	return envVars
 }
 
-// Returns the MinIO pods metadata set in configuration.
-// If a user specifies metadata in the spec we return that
-// metadata.
-func minioMetadata(mi *miniov1.MinIOInstance) metav1.ObjectMeta {
-	meta := metav1.ObjectMeta{}
-	if mi.HasMetadata() {
-		meta = *mi.Spec.Metadata
+// InheritMeta returns the labels and annotations that cascade from the
+// top-level MinIOInstance ObjectMeta onto every child resource the operator
+// creates for it, merged with any resource-specific extraLabels. It is
+// shared by this package and the services/secrets resource builders so a
+// label or annotation placed on the tenant itself (for cost allocation,
+// backup selectors, network policies, etc.) reaches the StatefulSet, its
+// pod template, its PVCs, and any secrets those packages create, not just
+// Spec.Metadata. extraLabels are applied last and override the tenant's own
+// labels, so callers pass reserved operator labels (e.g.
+// miniov1.InstanceLabel) as extraLabels or apply them afterwards, and they
+// always win.
+func InheritMeta(mi *miniov1.MinIOInstance, extraLabels map[string]string) metav1.ObjectMeta {
+	meta := metav1.ObjectMeta{
+		Labels:      make(map[string]string),
+		Annotations: make(map[string]string),
 	}
-	// Initialize empty fields
-	if meta.Labels == nil {
-		meta.Labels = make(map[string]string)
+	for k, v := range mi.Labels {
+		meta.Labels[k] = v
 	}
-	if meta.Annotations == nil {
-		meta.Annotations = make(map[string]string)
+	for k, v := range mi.Annotations {
+		meta.Annotations[k] = v
 	}
-	// Add the additional label used by StatefulSet spec
-	for k, v := range mi.MinIOPodLabels() {
+	for k, v := range extraLabels {
 		meta.Labels[k] = v
 	}
+	return meta
+}
+
+// Returns the MinIO pods metadata set in configuration.
+// Cascades, in order of increasing precedence: the MinIOInstance's own
+// ObjectMeta (via InheritMeta), Spec.Metadata, and finally the operator's
+// reserved labels, which always win.
+func minioMetadata(mi *miniov1.MinIOInstance, zone *miniov1.Zone) metav1.ObjectMeta {
+	meta := InheritMeta(mi, nil)
+	if mi.HasMetadata() {
+		for k, v := range mi.Spec.Metadata.Labels {
+			meta.Labels[k] = v
+		}
+		for k, v := range mi.Spec.Metadata.Annotations {
+			meta.Annotations[k] = v
+		}
+	}
 	// Add the Selector labels set by user
 	if mi.HasSelector() {
 		for k, v := range mi.Spec.Selector.MatchLabels {
 			meta.Labels[k] = v
 		}
 	}
+	// Add the additional labels used by the zone's StatefulSet selector
+	// last: these are reserved and must always win, or a user-supplied
+	// Spec.Selector could clobber InstanceLabel/ZoneLabel and break the
+	// StatefulSet's own selector match.
+	for k, v := range zoneSelector(mi, zone) {
+		meta.Labels[k] = v
+	}
+	// Add the Prometheus scrape annotations so users no longer have to
+	// hand-annotate tenants via Spec.Metadata to get scraped.
+	if mi.HasMonitoringEnabled() {
+		meta.Annotations["prometheus.io/scrape"] = "true"
+		meta.Annotations["prometheus.io/port"] = strconv.Itoa(miniov1.MinIOPort)
+		meta.Annotations["prometheus.io/path"] = miniov1.PrometheusMetricsPath
+	}
 	return meta
 }
 
@@ -151,16 +320,73 @@ func volumeMounts(mi *miniov1.MinIOInstance) []corev1.VolumeMount {
 	return mounts
 }
 
+// defaultHostsTemplate is the peer hostname pattern used when
+// mi.Spec.HostsTemplate is unset. It must match the actual pod DNS names
+// produced by NewForMinIO, which names each zone's StatefulSet
+// "<MinIOStatefulSetName()>-<zone>" and therefore each pod
+// "<MinIOStatefulSetName()>-<zone>-<ordinal>".
+const defaultHostsTemplate = "{{.StatefulSet}}-{{.Zone}}-{{.Ordinal}}.{{.Service}}.{{.Namespace}}.svc.{{.ClusterDomain}}"
+
+// minioHosts returns the peer hostnames used to assemble the `server` args.
+// The template is evaluated once per replica, in zone order, with a
+// minioHostsTemplateData context: mi.Spec.HostsTemplate if set, otherwise
+// defaultHostsTemplate, which derives the zone-suffixed name directly
+// instead of relying on the non-zone-aware mi.MinIOHosts().
+func minioHosts(mi *miniov1.MinIOInstance, serviceName string) ([]string, error) {
+	hostsTemplate := mi.Spec.HostsTemplate
+	if hostsTemplate == "" {
+		hostsTemplate = defaultHostsTemplate
+	}
+
+	tmpl, err := template.New("hosts").Parse(hostsTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("parsing spec.hostsTemplate: %v", err)
+	}
+
+	statefulSetName := mi.MinIOStatefulSetName()
+	clusterDomain := mi.Spec.ClusterDomain
+	if clusterDomain == "" {
+		clusterDomain = defaultClusterDomain
+	}
+
+	var hosts []string
+	index := 0
+	for zoneIndex, zone := range mi.Spec.Zones {
+		for ordinal := 0; ordinal < zone.Servers; ordinal++ {
+			data := minioHostsTemplateData{
+				StatefulSet:   statefulSetName,
+				Zone:          zone.Name,
+				Service:       serviceName,
+				Namespace:     mi.Namespace,
+				ClusterDomain: clusterDomain,
+				Index:         index,
+				ZoneIndex:     zoneIndex,
+				Ordinal:       ordinal,
+			}
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, data); err != nil {
+				return nil, fmt.Errorf("executing spec.hostsTemplate for replica %d: %v", index, err)
+			}
+			hosts = append(hosts, buf.String())
+			index++
+		}
+	}
+	return hosts, nil
+}
+
 // Builds the MinIO container for a MinIOInstance.
-func minioServerContainer(mi *miniov1.MinIOInstance, serviceName string) corev1.Container {
+func minioServerContainer(mi *miniov1.MinIOInstance, serviceName string) (corev1.Container, error) {
 	args := []string{"server"}
 
-	if mi.Spec.Zones[0].Servers == 1 {
+	if mi.MinIOReplicas() == 1 {
 		// to run in standalone mode we must pass the path
 		args = append(args, miniov1.MinIOVolumeMountPath)
 	} else {
 		// append all the MinIOInstance replica URLs
-		hosts := mi.MinIOHosts()
+		hosts, err := minioHosts(mi, serviceName)
+		if err != nil {
+			return corev1.Container{}, err
+		}
 		for _, h := range hosts {
 			args = append(args, fmt.Sprintf("%s://"+h+"%s", miniov1.Scheme, mi.VolumePath()))
 		}
@@ -181,6 +407,23 @@ func minioServerContainer(mi *miniov1.MinIOInstance, serviceName string) corev1.
 		Resources:       mi.Spec.Resources,
 		LivenessProbe:   mi.Spec.Liveness,
 		ReadinessProbe:  mi.Spec.Readiness,
+	}, nil
+}
+
+// monitoringSidecarContainer builds the optional Prometheus scraping sidecar
+// for a MinIOInstance with Spec.Monitoring.Sidecar enabled. It scrapes the
+// same cluster metrics endpoint advertised via the pod's prometheus.io
+// annotations, so it can be wired into a ServiceMonitor by the caller when
+// the Prometheus Operator CRDs are present in the cluster.
+func monitoringSidecarContainer(mi *miniov1.MinIOInstance) corev1.Container {
+	return corev1.Container{
+		Name:            monitoringSidecarName,
+		Image:           mi.SidecarImage(),
+		ImagePullPolicy: miniov1.DefaultImagePullPolicy,
+		Args: []string{
+			fmt.Sprintf("--minio.scrape-url=http://localhost:%d%s", miniov1.MinIOPort, miniov1.PrometheusMetricsPath),
+		},
+		Resources: mi.Spec.Monitoring.SidecarResources,
 	}
 }
 
@@ -204,26 +447,42 @@ func minioSecurityContext(mi *miniov1.MinIOInstance) *corev1.PodSecurityContext
 	return &securityContext
 }
 
-func getVolumesForContainer(mi *miniov1.MinIOInstance) []corev1.Volume {
+func getVolumesForContainer(zone *miniov1.Zone, mi *miniov1.MinIOInstance) []corev1.Volume {
 	var podVolumes = []corev1.Volume{}
 	// This is the case where user didn't provide a volume claim template and we deploy a
 	// EmptyDir based MinIO deployment
 	if mi.Spec.VolumeClaimTemplate == nil {
-		for _, z := range mi.Spec.Zones {
-			podVolumes = append(podVolumes, corev1.Volume{Name: z.Name,
-				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: ""}}})
-		}
+		podVolumes = append(podVolumes, corev1.Volume{Name: zone.Name,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: ""}}})
 	}
 	return podVolumes
 }
 
-// NewForMinIO creates a new StatefulSet for the given Cluster.
-func NewForMinIO(mi *miniov1.MinIOInstance, serviceName string) *appsv1.StatefulSet {
+// zoneSelector returns the labels that uniquely select the pods of a single
+// zone's StatefulSet. It extends mi.MinIOPodLabels() (shared across every
+// zone, so the headless Service can still address the whole tenant) with a
+// zone-specific label, since each zone gets its own StatefulSet and
+// Kubernetes requires their selectors to be disjoint.
+func zoneSelector(mi *miniov1.MinIOInstance, zone *miniov1.Zone) map[string]string {
+	return map[string]string{
+		miniov1.InstanceLabel: mi.MinIOStatefulSetName(),
+		miniov1.ZoneLabel:     zone.Name,
+	}
+}
+
+// NewForMinIO creates a new StatefulSet for a single zone of the given
+// MinIOInstance. The operator calls this once per entry in mi.Spec.Zones so
+// each zone can be rolled and disrupted independently; minioHosts still
+// enumerates every zone's replicas so the distributed `server` args are
+// correct regardless of which zone a given StatefulSet belongs to.
+// An error is returned if mi.Spec.HostsTemplate fails to parse or execute;
+// callers should surface it on the tenant status rather than requeue blindly.
+func NewForMinIO(mi *miniov1.MinIOInstance, zone *miniov1.Zone, serviceName string) (*appsv1.StatefulSet, error) {
 	var secretName string
 
 	// If a PV isn't specified just use a EmptyDir volume
-	var podVolumes = getVolumesForContainer(mi)
-	var replicas = mi.MinIOReplicas()
+	var podVolumes = getVolumesForContainer(zone, mi)
+	var replicas = int32(zone.Servers)
 
 	var keyPaths = []corev1.KeyToPath{
 		{Key: "public.crt", Path: "public.crt"},
@@ -271,7 +530,10 @@ func NewForMinIO(mi *miniov1.MinIOInstance, serviceName string) *appsv1.Stateful
 				},
 			},
 		}
-		if mi.HasKESEnabled() {
+		// Client cert/key material is only needed when KES is the active
+		// KMS backend; Vault/AWS/GCP authenticate over plain HTTPS using
+		// the env vars from minioEnvironmentVars instead.
+		if activeKMSProvider(mi) == kmsProviderKES {
 			sources = append(sources, []corev1.VolumeProjection{
 				{
 					Secret: &corev1.SecretProjection{
@@ -301,12 +563,33 @@ func NewForMinIO(mi *miniov1.MinIOInstance, serviceName string) *appsv1.Stateful
 		})
 	}
 
-	containers := []corev1.Container{minioServerContainer(mi, serviceName)}
+	minioContainer, err := minioServerContainer(mi, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	containers := []corev1.Container{minioContainer}
+	if mi.HasMonitoringEnabled() && mi.Spec.Monitoring.Sidecar {
+		containers = append(containers, monitoringSidecarContainer(mi))
+	}
+
+	ssMeta := InheritMeta(mi, zoneSelector(mi, zone))
+
+	updateStrategy := appsv1.StatefulSetUpdateStrategy{Type: miniov1.DefaultUpdateStrategy}
+	if zone.UpdateStrategy.Type != "" {
+		updateStrategy = zone.UpdateStrategy
+	}
+
+	podManagementPolicy := mi.Spec.PodManagementPolicy
+	if zone.PodManagementPolicy != "" {
+		podManagementPolicy = zone.PodManagementPolicy
+	}
 
 	ss := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: mi.Namespace,
-			Name:      mi.Name,
+			Namespace:   mi.Namespace,
+			Name:        mi.MinIOStatefulSetName() + "-" + zone.Name,
+			Labels:      ssMeta.Labels,
+			Annotations: ssMeta.Annotations,
 			OwnerReferences: []metav1.OwnerReference{
 				*metav1.NewControllerRef(mi, schema.GroupVersionKind{
 					Group:   miniov1.SchemeGroupVersion.Group,
@@ -316,19 +599,15 @@ func NewForMinIO(mi *miniov1.MinIOInstance, serviceName string) *appsv1.Stateful
 			},
 		},
 		Spec: appsv1.StatefulSetSpec{
-			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
-				Type: miniov1.DefaultUpdateStrategy,
-			},
-			PodManagementPolicy: mi.Spec.PodManagementPolicy,
+			UpdateStrategy:      updateStrategy,
+			PodManagementPolicy: podManagementPolicy,
 			Selector: &metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					miniov1.InstanceLabel: mi.MinIOStatefulSetName(),
-				},
+				MatchLabels: zoneSelector(mi, zone),
 			},
 			ServiceName: serviceName,
 			Replicas:    &replicas,
 			Template: corev1.PodTemplateSpec{
-				ObjectMeta: minioMetadata(mi),
+				ObjectMeta: minioMetadata(mi, zone),
 				Spec: corev1.PodSpec{
 					Containers:       containers,
 					Volumes:          podVolumes,
@@ -344,12 +623,27 @@ func NewForMinIO(mi *miniov1.MinIOInstance, serviceName string) *appsv1.Stateful
 	}
 
 	if mi.Spec.VolumeClaimTemplate != nil {
+		pvcMeta := InheritMeta(mi, mi.Spec.VolumeClaimTemplate.Labels)
 		pvClaim := *mi.Spec.VolumeClaimTemplate
+		pvClaim.Labels = pvcMeta.Labels
+		// Build a fresh map rather than merging into
+		// mi.Spec.VolumeClaimTemplate.Annotations directly: pvClaim is a
+		// shallow copy, so its Annotations field still aliases the
+		// original map, and mi may be a cached object shared across zones
+		// and reconciles.
+		annotations := make(map[string]string, len(mi.Spec.VolumeClaimTemplate.Annotations)+len(pvcMeta.Annotations))
+		for k, v := range mi.Spec.VolumeClaimTemplate.Annotations {
+			annotations[k] = v
+		}
+		for k, v := range pvcMeta.Annotations {
+			annotations[k] = v
+		}
+		pvClaim.Annotations = annotations
 		name := pvClaim.Name
 		for i := 0; i < mi.Spec.VolumesPerServer; i++ {
 			pvClaim.Name = name + strconv.Itoa(i)
 			ss.Spec.VolumeClaimTemplates = append(ss.Spec.VolumeClaimTemplates, pvClaim)
 		}
 	}
-	return ss
-}
\ No newline at end of file
+	return ss, nil
+}
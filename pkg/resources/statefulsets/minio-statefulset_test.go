@@ -0,0 +1,100 @@
+/*
+ * Copyright (C) 2020, MinIO, Inc.
+ *
+ * This code is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License, version 3,
+ * as published by the Free Software Foundation.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License, version 3,
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>
+ *
+ */
+
+package statefulsets
+
+import (
+	"reflect"
+	"testing"
+
+	miniov1 "github.com/minio/minio-operator/pkg/apis/operator.min.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestInstance(zones []miniov1.Zone) *miniov1.MinIOInstance {
+	return &miniov1.MinIOInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "minio-ns"},
+		Spec:       miniov1.MinIOInstanceSpec{Zones: zones},
+	}
+}
+
+func TestMinioHostsDefaultTemplateIsZoneAware(t *testing.T) {
+	mi := newTestInstance([]miniov1.Zone{
+		{Name: "zone-0", Servers: 2},
+		{Name: "zone-1", Servers: 1},
+	})
+
+	hosts, err := minioHosts(mi, "test-svc")
+	if err != nil {
+		t.Fatalf("minioHosts returned error: %v", err)
+	}
+
+	want := []string{
+		"test-ss-zone-0-0.test-svc.minio-ns.svc.cluster.local",
+		"test-ss-zone-0-1.test-svc.minio-ns.svc.cluster.local",
+		"test-ss-zone-1-0.test-svc.minio-ns.svc.cluster.local",
+	}
+	if !reflect.DeepEqual(hosts, want) {
+		t.Fatalf("minioHosts() = %v, want %v", hosts, want)
+	}
+}
+
+func TestMinioHostsCustomTemplate(t *testing.T) {
+	mi := newTestInstance([]miniov1.Zone{{Name: "zone-0", Servers: 1}})
+	mi.Spec.HostsTemplate = "{{.StatefulSet}}-{{.Zone}}-{{.Ordinal}}.custom.example.com"
+
+	hosts, err := minioHosts(mi, "test-svc")
+	if err != nil {
+		t.Fatalf("minioHosts returned error: %v", err)
+	}
+
+	want := []string{"test-ss-zone-0-0.custom.example.com"}
+	if !reflect.DeepEqual(hosts, want) {
+		t.Fatalf("minioHosts() = %v, want %v", hosts, want)
+	}
+}
+
+func TestMinioHostsInvalidTemplate(t *testing.T) {
+	mi := newTestInstance([]miniov1.Zone{{Name: "zone-0", Servers: 1}})
+	mi.Spec.HostsTemplate = "{{.NoSuchField}}"
+
+	if _, err := minioHosts(mi, "test-svc"); err == nil {
+		t.Fatal("minioHosts() expected an error for an invalid template field, got nil")
+	}
+}
+
+func TestNewForMinIODoesNotMutateSharedVolumeClaimTemplateAnnotations(t *testing.T) {
+	mi := newTestInstance([]miniov1.Zone{{Name: "zone-0", Servers: 1}})
+	mi.Spec.Image = "minio/minio"
+	mi.Spec.VolumesPerServer = 1
+	mi.Spec.VolumeClaimTemplate = &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "data",
+			Annotations: map[string]string{"user-set": "keep-me"},
+		},
+	}
+	originalAnnotations := mi.Spec.VolumeClaimTemplate.Annotations
+
+	if _, err := NewForMinIO(mi, &mi.Spec.Zones[0], "test-svc"); err != nil {
+		t.Fatalf("NewForMinIO returned error: %v", err)
+	}
+
+	if len(originalAnnotations) != 1 || originalAnnotations["user-set"] != "keep-me" {
+		t.Fatalf("mi.Spec.VolumeClaimTemplate.Annotations was mutated in place: %v", originalAnnotations)
+	}
+}